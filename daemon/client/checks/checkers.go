@@ -0,0 +1,148 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExternalSystemChecker runs CheckExternalSystem against a single endpoint.
+type ExternalSystemChecker struct {
+	Config ExternalCheckConfig
+}
+
+func (c ExternalSystemChecker) Name() string { return "external_system:" + c.Config.URL }
+
+func (c ExternalSystemChecker) Run(ctx context.Context) Result {
+	err := CheckExternalSystem(ctx, c.Config)
+	return Result{Up: err == nil, Err: err}
+}
+
+// TimeSyncChecker queries a fixed set of NTP servers in order and reports
+// the offset of the first one it can reach, with Err set from that same
+// round trip. Unlike CheckTimeSync, it does not go on to the next server
+// when the first reachable one's offset exceeds MaxOffset.
+type TimeSyncChecker struct {
+	Servers   []string
+	MaxOffset time.Duration
+}
+
+func (c TimeSyncChecker) Name() string { return "ntp" }
+
+func (c TimeSyncChecker) Run(ctx context.Context) Result {
+	var lastErr error
+	for _, server := range c.Servers {
+		offset, err := queryNTPOffset(ctx, server, 5*time.Second)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		up := offset >= -c.MaxOffset && offset <= c.MaxOffset
+		var rangeErr error
+		if !up {
+			rangeErr = fmt.Errorf("clock offset to %s is %v, which exceeds the allowed %v", server, offset, c.MaxOffset)
+		}
+
+		return Result{
+			Up:  up,
+			Err: rangeErr,
+			Value: map[string]float64{
+				"offset_seconds": offset.Seconds(),
+			},
+		}
+	}
+
+	return Result{Err: lastErr}
+}
+
+// ChronydChecker queries the local chronyd cmdmon socket and applies the
+// same offset check as CheckChronyd, also reporting the offset as a value.
+type ChronydChecker struct {
+	MaxOffset time.Duration
+}
+
+func (c ChronydChecker) Name() string { return "chrony" }
+
+func (c ChronydChecker) Run(ctx context.Context) Result {
+	offset, err := queryChronyOffset(ctx, 5*time.Second)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	err = nil
+	up := offset >= -c.MaxOffset && offset <= c.MaxOffset
+	if !up {
+		err = fmt.Errorf("chronyd last offset is %v, which exceeds the allowed %v", offset, c.MaxOffset)
+	}
+
+	return Result{
+		Up:  up,
+		Err: err,
+		Value: map[string]float64{
+			"offset_seconds": offset.Seconds(),
+		},
+	}
+}
+
+// VolumeGroupChecker checks a single LVM volume group against a VGThreshold.
+type VolumeGroupChecker struct {
+	Threshold VGThreshold
+}
+
+func (c VolumeGroupChecker) Name() string { return "vg:" + c.Threshold.Name }
+
+func (c VolumeGroupChecker) Run(ctx context.Context) Result {
+	freePercent, err := CheckVolumeGroup(ctx, c.Threshold)
+	return Result{
+		Up:  err == nil,
+		Err: err,
+		Value: map[string]float64{
+			"free_percent": freePercent,
+		},
+	}
+}
+
+// LogicalVolumeChecker checks a single LVM thin pool against an LVThreshold.
+type LogicalVolumeChecker struct {
+	Threshold LVThreshold
+}
+
+func (c LogicalVolumeChecker) Name() string { return "lvs:" + c.Threshold.Name }
+
+func (c LogicalVolumeChecker) Run(ctx context.Context) Result {
+	dataPercent, metadataPercent, err := CheckLogicalVolume(ctx, c.Threshold)
+	return Result{
+		Up:  err == nil,
+		Err: err,
+		Value: map[string]float64{
+			"data_percent":     dataPercent,
+			"metadata_percent": metadataPercent,
+		},
+	}
+}
+
+// ClusterDNSChecker runs CheckClusterDNS against a fixed set of names
+// through a single Resolver.
+type ClusterDNSChecker struct {
+	Resolver Resolver
+	Names    []string
+}
+
+func (c ClusterDNSChecker) Name() string { return "dns" }
+
+func (c ClusterDNSChecker) Run(ctx context.Context) Result {
+	results := CheckClusterDNS(ctx, c.Resolver, c.Names)
+
+	value := make(map[string]float64, len(results))
+	var firstErr error
+	for _, r := range results {
+		value[r.Name+"_latency_seconds"] = r.Latency.Seconds()
+		value[r.Name+"_rcode"] = float64(r.RCode)
+		if r.Err != nil && firstErr == nil {
+			firstErr = r.Err
+		}
+	}
+
+	return Result{Up: firstErr == nil, Err: firstErr, Value: value}
+}
@@ -0,0 +1,179 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"time"
+)
+
+// chronyCmdPort is the UDP port chronyd listens on for its binary command
+// protocol (cmdmon), as opposed to the NTP protocol itself on 123/udp.
+const chronyCmdPort = 323
+
+// chronyProtocolVersion is the cmdmon protocol version this client speaks.
+// chronyd rejects requests with an unsupported version outright.
+const chronyProtocolVersion = 6
+
+const (
+	chronyPktTypeCmdRequest = 1
+	chronyPktTypeCmdReply   = 2
+)
+
+const (
+	chronyReqTracking = 33
+	chronyRpyTracking = 5
+)
+
+// chronyStatusOK is the cmdmon reply status code meaning the request
+// succeeded. Anything else (e.g. permission denied for an unauthenticated
+// request) means the payload that follows is not a tracking report at all.
+const chronyStatusOK = 0
+
+// chronyReplyHeader is the fixed 28-byte header chronyd prefixes every
+// cmdmon reply with, before any command-specific payload.
+type chronyReplyHeader struct {
+	Version  uint8
+	PktType  uint8
+	Res1     uint8
+	Res2     uint8
+	Command  uint16
+	Reply    uint16
+	Status   uint16
+	Pad1     uint16
+	Pad2     uint16
+	Pad3     uint16
+	Sequence uint32
+	Pad4     uint32
+	Pad5     uint32
+}
+
+// chronyRequestHeader is the fixed 20-byte header chronyd expects in front
+// of every cmdmon request. Tracking requests carry no further payload.
+type chronyRequestHeader struct {
+	Version  uint8
+	PktType  uint8
+	Res1     uint8
+	Res2     uint8
+	Command  uint16
+	Attempt  uint16
+	Sequence uint32
+	Pad1     uint32
+	Pad2     uint32
+}
+
+// CheckChronyd asks a local chronyd for its tracking status over the binary
+// cmdmon protocol on 323/udp, rather than parsing the text table printed by
+// `chronyc tracking`, and returns an error if the reported offset exceeds
+// maxOffset.
+func CheckChronyd(maxOffset time.Duration) error {
+	offset, err := queryChronyOffset(context.Background(), 5*time.Second)
+	if err != nil {
+		return err
+	}
+
+	log.Println("chronyd last offset is", offset)
+
+	if offset < -maxOffset || offset > maxOffset {
+		return fmt.Errorf("chronyd last offset is %v, which exceeds the allowed %v", offset, maxOffset)
+	}
+
+	return nil
+}
+
+// queryChronyOffset opens a cmdmon connection to the local chronyd, asks for
+// its tracking status, and returns the last measured clock offset. ctx
+// bounds the whole exchange in addition to timeout, so a caller such as the
+// Checker registry can cancel it early rather than waiting out timeout.
+func queryChronyOffset(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	conn, stop, err := dialContext(ctx, "udp", net.JoinHostPort("127.0.0.1", fmt.Sprint(chronyCmdPort)), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("dial chronyd cmdmon socket: %w", err)
+	}
+	defer conn.Close()
+	defer stop()
+
+	req := chronyRequestHeader{
+		Version: chronyProtocolVersion,
+		PktType: chronyPktTypeCmdRequest,
+		Command: chronyReqTracking,
+	}
+	if err := binary.Write(conn, binary.BigEndian, &req); err != nil {
+		return 0, fmt.Errorf("send cmdmon tracking request: %w", err)
+	}
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return 0, fmt.Errorf("read cmdmon tracking reply: %w", err)
+	}
+
+	offset, err := parseChronyTrackingReply(buf[:n])
+	if err != nil {
+		return 0, fmt.Errorf("parse cmdmon tracking reply: %w", err)
+	}
+
+	return offset, nil
+}
+
+// chronyReplyHeaderLen is the size, in bytes, of the cmdmon reply header
+// that precedes the command-specific payload (mirrors chronyReplyHeader).
+const chronyReplyHeaderLen = 28
+
+// lastOffsetFieldOffset is the byte offset of the "last offset" chrony Float
+// within the RPY_Tracking payload, counted from the start of that payload.
+const lastOffsetFieldOffset = 48
+
+// parseChronyTrackingReply decodes the last-offset field out of a raw
+// RPY_Tracking datagram and converts it from chrony's custom Float encoding
+// to a time.Duration. It validates the reply header's packet type, echoed
+// command, reply type, and status before trusting any payload bytes, so an
+// error/unsupported-command reply (e.g. permission denied) is reported as
+// an error instead of being misread as a clock offset.
+func parseChronyTrackingReply(raw []byte) (time.Duration, error) {
+	if len(raw) < chronyReplyHeaderLen+lastOffsetFieldOffset+4 {
+		return 0, fmt.Errorf("short cmdmon reply (%d bytes)", len(raw))
+	}
+
+	var header chronyReplyHeader
+	if err := binary.Read(bytes.NewReader(raw[:chronyReplyHeaderLen]), binary.BigEndian, &header); err != nil {
+		return 0, fmt.Errorf("decode cmdmon reply header: %w", err)
+	}
+
+	if header.PktType != chronyPktTypeCmdReply {
+		return 0, fmt.Errorf("unexpected cmdmon packet type %d", header.PktType)
+	}
+	if header.Command != chronyReqTracking || header.Reply != chronyRpyTracking {
+		return 0, fmt.Errorf("unexpected cmdmon reply: command=%d reply=%d, expected tracking (command=%d reply=%d)",
+			header.Command, header.Reply, chronyReqTracking, chronyRpyTracking)
+	}
+	if header.Status != chronyStatusOK {
+		return 0, fmt.Errorf("chronyd rejected tracking request with status %d", header.Status)
+	}
+
+	raw32 := binary.BigEndian.Uint32(raw[chronyReplyHeaderLen+lastOffsetFieldOffset:])
+	seconds := chronyFloatToFloat64(raw32)
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// chronyFloatToFloat64 decodes chrony's compact "Float" wire format: a
+// 7-bit base-2 exponent followed by a 25-bit signed mantissa, both packed
+// into a single big-endian uint32 (see chrony's candm.h).
+func chronyFloatToFloat64(raw uint32) float64 {
+	exp := int32(raw>>25) & 0x7f
+	if exp >= 64 {
+		exp -= 128
+	}
+	exp -= 25
+
+	mantissa := int32(raw & 0x01ffffff)
+	if mantissa >= 1<<24 {
+		mantissa -= 1 << 25
+	}
+
+	return float64(mantissa) * math.Pow(2, float64(exp))
+}
@@ -0,0 +1,91 @@
+package checks
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func TestChronyFloatToFloat64(t *testing.T) {
+	cases := []struct {
+		raw  uint32
+		want float64
+	}{
+		{raw: 0xe880189d, want: 6.108100205892697e-05},
+		{raw: 0xe92e48e9, want: -9.999999747378752e-05},
+		{raw: 0x04800000, want: 1.0},
+		{raw: 0x03000000, want: -1.0},
+		{raw: 0x02800000, want: 0.5},
+		{raw: 0x10f6e979, want: 123.45600128173828},
+		{raw: 0x00000000, want: 0.0},
+	}
+
+	for _, c := range cases {
+		got := chronyFloatToFloat64(c.raw)
+		if math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("chronyFloatToFloat64(0x%08x) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
+
+// buildChronyReply assembles a raw RPY_Tracking-shaped cmdmon datagram with
+// the given header fields and last-offset Float value, mirroring what a
+// real chronyd would put on the wire.
+func buildChronyReply(t *testing.T, command, reply, status uint16, lastOffsetRaw uint32) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	header := chronyReplyHeader{
+		Version: chronyProtocolVersion,
+		PktType: chronyPktTypeCmdReply,
+		Command: command,
+		Reply:   reply,
+		Status:  status,
+	}
+	if err := binary.Write(buf, binary.BigEndian, &header); err != nil {
+		t.Fatalf("encode header: %v", err)
+	}
+
+	payload := make([]byte, lastOffsetFieldOffset+4)
+	binary.BigEndian.PutUint32(payload[lastOffsetFieldOffset:], lastOffsetRaw)
+	buf.Write(payload)
+
+	return buf.Bytes()
+}
+
+func TestParseChronyTrackingReply(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		raw := buildChronyReply(t, chronyReqTracking, chronyRpyTracking, chronyStatusOK, 0x04800000)
+
+		offset, err := parseChronyTrackingReply(raw)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if offset.Seconds() != 1.0 {
+			t.Errorf("offset = %v, want 1s", offset)
+		}
+	})
+
+	t.Run("rejected status is an error, not a bogus offset", func(t *testing.T) {
+		raw := buildChronyReply(t, chronyReqTracking, chronyRpyTracking, chronyStatusOK+1, 0x04800000)
+
+		if _, err := parseChronyTrackingReply(raw); err == nil {
+			t.Fatal("expected an error for a non-OK status, got nil")
+		}
+	})
+
+	t.Run("mismatched reply type is an error", func(t *testing.T) {
+		raw := buildChronyReply(t, chronyReqTracking, chronyRpyTracking+1, chronyStatusOK, 0x04800000)
+
+		if _, err := parseChronyTrackingReply(raw); err == nil {
+			t.Fatal("expected an error for an unexpected reply type, got nil")
+		}
+	})
+
+	t.Run("short reply is an error", func(t *testing.T) {
+		if _, err := parseChronyTrackingReply([]byte{0, 0, 0}); err == nil {
+			t.Fatal("expected an error for a short reply, got nil")
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Resolver looks up a single DNS name and reports how it went, so
+// CheckClusterDNS can tell "kube-dns service VIP unreachable" apart from
+// "upstream broken" instead of only getting net.LookupIP's opaque error.
+type Resolver interface {
+	Resolve(ctx context.Context, name string) (DNSResult, error)
+}
+
+// DNSResult is what a Resolver learned about a single name.
+type DNSResult struct {
+	Name    string
+	IPs     []net.IP
+	RCode   int
+	Latency time.Duration
+}
+
+// PlainResolver queries a specific DNS server directly over UDP (falling
+// back to TCP on truncation) rather than going through the host resolver,
+// so a failure can be pinned on a specific server such as the cluster DNS
+// service VIP.
+type PlainResolver struct {
+	Server string // host:port, e.g. "172.30.0.10:53"
+}
+
+func (r PlainResolver) Resolve(ctx context.Context, name string) (DNSResult, error) {
+	msg := newQuery(name)
+
+	client := &dns.Client{Net: "udp", Timeout: 5 * time.Second}
+	reply, rtt, err := client.ExchangeContext(ctx, msg, r.Server)
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("query %s via %s: %w", name, r.Server, err)
+	}
+
+	if reply.Truncated {
+		client.Net = "tcp"
+		reply, rtt, err = client.ExchangeContext(ctx, msg, r.Server)
+		if err != nil {
+			return DNSResult{Name: name}, fmt.Errorf("query %s via %s (tcp retry): %w", name, r.Server, err)
+		}
+	}
+
+	return dnsResultFromReply(name, reply, rtt), nil
+}
+
+// DoTResolver queries a DNS server over DNS-over-TLS (RFC 7858) on port
+// 853, so the path between the daemon and the resolver can't be tampered
+// with or observed in transit.
+type DoTResolver struct {
+	Server     string // host:port, defaults to port 853 if no port given
+	ServerName string // TLS SNI / certificate name to verify against
+}
+
+func (r DoTResolver) Resolve(ctx context.Context, name string) (DNSResult, error) {
+	server := r.Server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "853")
+	}
+
+	client := &dns.Client{
+		Net:       "tcp-tls",
+		Timeout:   5 * time.Second,
+		TLSConfig: &tls.Config{ServerName: r.ServerName},
+	}
+
+	reply, rtt, err := client.ExchangeContext(ctx, newQuery(name), server)
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("query %s via DoT %s: %w", name, server, err)
+	}
+
+	return dnsResultFromReply(name, reply, rtt), nil
+}
+
+// DoHResolver queries a DNS server over DNS-over-HTTPS (RFC 8484). The
+// server's hostname is resolved against BootstrapIPs rather than the host
+// resolver, to avoid the chicken-and-egg problem of needing working DNS to
+// find the DoH endpoint in the first place.
+type DoHResolver struct {
+	URL          string // e.g. "https://dns.example.com/dns-query"
+	BootstrapIPs []string
+}
+
+func (r DoHResolver) Resolve(ctx context.Context, name string) (DNSResult, error) {
+	msg := newQuery(name)
+	packed, err := msg.Pack()
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("pack DNS query for %s: %w", name, err)
+	}
+
+	client := r.httpClient()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(packed))
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("build DoH request for %s: %w", name, err)
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("query %s via DoH %s: %w", name, r.URL, err)
+	}
+	defer resp.Body.Close()
+	rtt := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK {
+		return DNSResult{Name: name}, fmt.Errorf("DoH query for %s via %s returned HTTP %d", name, r.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("read DoH response for %s: %w", name, err)
+	}
+
+	reply := new(dns.Msg)
+	if err := reply.Unpack(body); err != nil {
+		return DNSResult{Name: name}, fmt.Errorf("unpack DoH response for %s: %w", name, err)
+	}
+
+	return dnsResultFromReply(name, reply, rtt), nil
+}
+
+// httpClient builds an http.Client that resolves the DoH server's hostname
+// against BootstrapIPs instead of the host resolver.
+func (r DoHResolver) httpClient() *http.Client {
+	if len(r.BootstrapIPs) == 0 {
+		return &http.Client{Timeout: 5 * time.Second}
+	}
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			var lastErr error
+			for _, ip := range r.BootstrapIPs {
+				conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+				if err == nil {
+					return conn, nil
+				}
+				lastErr = err
+			}
+			return nil, fmt.Errorf("dial bootstrap IPs %v: %w", r.BootstrapIPs, lastErr)
+		},
+	}
+
+	return &http.Client{Transport: transport, Timeout: 5 * time.Second}
+}
+
+// newQuery builds a recursive A-record query for name.
+func newQuery(name string) *dns.Msg {
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), dns.TypeA)
+	msg.RecursionDesired = true
+	return msg
+}
+
+func dnsResultFromReply(name string, reply *dns.Msg, rtt time.Duration) DNSResult {
+	result := DNSResult{Name: name, RCode: reply.Rcode, Latency: rtt}
+
+	for _, rr := range reply.Answer {
+		if a, ok := rr.(*dns.A); ok {
+			result.IPs = append(result.IPs, a.A)
+		}
+	}
+
+	return result
+}
+
+// ClusterDNSResult is the outcome of resolving a single name as part of
+// CheckClusterDNS.
+type ClusterDNSResult struct {
+	Name    string
+	RCode   int
+	Latency time.Duration
+	Err     error
+}
+
+// NewKubeDNSResolver returns a Resolver that queries the cluster's kube-dns
+// service VIP directly, bypassing the node's /etc/resolv.conf so a failure
+// can be attributed to the service itself rather than the host resolver.
+func NewKubeDNSResolver() Resolver {
+	return PlainResolver{Server: net.JoinHostPort(kubernetesIP, "53")}
+}
+
+// DefaultClusterDNSNames are the daemon's own service names, queried as a
+// baseline signal that cluster DNS is resolving at all.
+var DefaultClusterDNSNames = []string{
+	daemonDNSEndpoint,
+	daemonDNSServiceA,
+	daemonDNSServiceB,
+	daemonDNSServiceC,
+}
+
+// CheckClusterDNS resolves each of names against resolver and reports, per
+// name, the rcode and latency observed. This lets operators tell a broken
+// kube-dns service VIP apart from a broken upstream, and validate the
+// encrypted-DNS sidecars some clusters now front kube-dns with. ctx bounds
+// every resolution, so a caller such as the Checker registry can cancel it
+// early rather than waiting out each resolver's own timeout.
+func CheckClusterDNS(ctx context.Context, resolver Resolver, names []string) []ClusterDNSResult {
+	results := make([]ClusterDNSResult, len(names))
+
+	for i, name := range names {
+		res, err := resolver.Resolve(ctx, name)
+		if err == nil && res.RCode != dns.RcodeSuccess {
+			err = fmt.Errorf("resolving %s returned %s", name, dns.RcodeToString[res.RCode])
+		}
+		results[i] = ClusterDNSResult{
+			Name:    name,
+			RCode:   res.RCode,
+			Latency: res.Latency,
+			Err:     err,
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,166 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+)
+
+// ExternalCheckConfig controls how CheckExternalSystem validates an HTTPS
+// endpoint. The zero value checks plain reachability with a sane default
+// timeout and full certificate verification.
+type ExternalCheckConfig struct {
+	URL string
+
+	// CABundlePath, if set, is used instead of the system trust store to
+	// verify the server certificate.
+	CABundlePath string
+
+	// ClientCertPath and ClientKeyPath, if both set, are presented to the
+	// server for mTLS.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// InsecureSkipVerify disables certificate verification entirely. This
+	// must be opted into per-endpoint; it is never the default.
+	InsecureSkipVerify bool
+
+	// ExpectedStatusMin/Max bound the acceptable HTTP status code, both
+	// inclusive. The zero value accepts any 2xx or 3xx response.
+	ExpectedStatusMin int
+	ExpectedStatusMax int
+
+	// ExpectedBodyPattern, if set, must match somewhere in the response
+	// body or the check fails.
+	ExpectedBodyPattern string
+
+	// Timeout bounds the whole request. Defaults to 10s if zero.
+	Timeout time.Duration
+
+	// FollowRedirects allows the client to follow 3xx responses. Defaults
+	// to false, since most probes want to validate the endpoint itself
+	// rather than wherever it redirects to.
+	FollowRedirects bool
+}
+
+// CheckExternalSystem performs an HTTP(S) request against cfg.URL and
+// validates it against cfg's expectations. Unlike a bare reachability
+// check, this lets callers require a specific status range, a body
+// pattern, and (for https) a specific CA bundle or client certificate
+// instead of blindly trusting or skipping TLS verification. ctx bounds the
+// request in addition to cfg.Timeout, so a caller such as the Checker
+// registry can cancel it early.
+func CheckExternalSystem(ctx context.Context, cfg ExternalCheckConfig) error {
+	client, err := buildHTTPClient(cfg)
+	if err != nil {
+		return fmt.Errorf("build HTTP client for %s: %w", cfg.URL, err)
+	}
+
+	log.Println("Checking access to:", cfg.URL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.URL, nil)
+	if err != nil {
+		return fmt.Errorf("build request for %s: %w", cfg.URL, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		msg := "Call to " + cfg.URL + " failed"
+		log.Println(msg, ":", err)
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatusCode(resp.StatusCode, cfg); err != nil {
+		return fmt.Errorf("%s: %w", cfg.URL, err)
+	}
+
+	if cfg.ExpectedBodyPattern != "" {
+		if err := checkBodyPattern(resp.Body, cfg.ExpectedBodyPattern); err != nil {
+			return fmt.Errorf("%s: %w", cfg.URL, err)
+		}
+	}
+
+	return nil
+}
+
+func checkStatusCode(status int, cfg ExternalCheckConfig) error {
+	min, max := cfg.ExpectedStatusMin, cfg.ExpectedStatusMax
+	if min == 0 && max == 0 {
+		min, max = 200, 399
+	}
+
+	if status < min || status > max {
+		return fmt.Errorf("unexpected status code %d, expected %d-%d", status, min, max)
+	}
+	return nil
+}
+
+func checkBodyPattern(body io.Reader, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid expected body pattern %q: %w", pattern, err)
+	}
+
+	buf, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+
+	if !re.Match(buf) {
+		return fmt.Errorf("response body did not match expected pattern %q", pattern)
+	}
+	return nil
+}
+
+// buildHTTPClient turns an ExternalCheckConfig into an *http.Client with the
+// requested CA bundle, client certificate, and timeout.
+func buildHTTPClient(cfg ExternalCheckConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CABundlePath != "" {
+		pem, err := os.ReadFile(cfg.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("read CA bundle %s: %w", cfg.CABundlePath, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CABundlePath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCertPath != "" && cfg.ClientKeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   timeout,
+	}
+
+	if !cfg.FollowRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	return client, nil
+}
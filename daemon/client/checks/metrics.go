@@ -0,0 +1,76 @@
+package checks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsCollector adapts a Registry to the prometheus.Collector interface
+// so that every scrape re-runs all checks and reports fresh values, rather
+// than exposing whatever the last background run happened to leave behind.
+type metricsCollector struct {
+	registry *Registry
+
+	up       *prometheus.Desc
+	duration *prometheus.Desc
+	value    *prometheus.Desc
+}
+
+// newMetricsCollector builds the prometheus.Collector backing
+// openshift_monitoring_check_up, openshift_monitoring_check_duration_seconds
+// and the per-check openshift_monitoring_check_value gauges.
+func newMetricsCollector(registry *Registry) *metricsCollector {
+	return &metricsCollector{
+		registry: registry,
+		up: prometheus.NewDesc(
+			"openshift_monitoring_check_up",
+			"Whether the named check last succeeded (1) or failed (0).",
+			[]string{"name"}, nil,
+		),
+		duration: prometheus.NewDesc(
+			"openshift_monitoring_check_duration_seconds",
+			"How long the named check took to run.",
+			[]string{"name"}, nil,
+		),
+		value: prometheus.NewDesc(
+			"openshift_monitoring_check_value",
+			"Check-specific measurement (e.g. chrony offset seconds, VG free percent).",
+			[]string{"name", "metric"}, nil,
+		),
+	}
+}
+
+func (m *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.up
+	ch <- m.duration
+	ch <- m.value
+}
+
+func (m *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, result := range m.registry.RunAll(context.Background()) {
+		up := 0.0
+		if result.Up {
+			up = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(m.up, prometheus.GaugeValue, up, result.Name)
+		ch <- prometheus.MustNewConstMetric(m.duration, prometheus.GaugeValue, result.Duration.Seconds(), result.Name)
+
+		for metric, value := range result.Value {
+			ch <- prometheus.MustNewConstMetric(m.value, prometheus.GaugeValue, value, result.Name, metric)
+		}
+	}
+}
+
+// MetricsHandler returns an http.Handler that runs every check in registry
+// on each scrape and renders the results in the Prometheus exposition
+// format, for operators who want to scrape this daemon instead of only
+// reading its logs.
+func MetricsHandler(registry *Registry) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(newMetricsCollector(registry))
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,37 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// dialContext dials network/addr honouring both ctx and timeout (used as
+// the fallback deadline when ctx carries none), and arranges for the
+// connection to be closed as soon as ctx is done so a blocking read/write
+// on it is interrupted immediately rather than only once its own deadline
+// elapses.
+func dialContext(ctx context.Context, network, addr string, timeout time.Duration) (net.Conn, func(), error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(timeout))
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	return conn, func() { close(stop) }, nil
+}
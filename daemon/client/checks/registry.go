@@ -0,0 +1,79 @@
+package checks
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Result is the outcome of running a single Checker.
+type Result struct {
+	Name     string
+	Up       bool
+	Err      error
+	Duration time.Duration
+	// Value optionally carries the headline measurement for the check
+	// (e.g. an NTP offset in seconds, or a VG free percentage), keyed by
+	// metric name so a single check can report more than one gauge.
+	Value map[string]float64
+}
+
+// Checker is anything the daemon can run as a health check and report
+// through the Registry and its Prometheus exporter.
+type Checker interface {
+	Name() string
+	Run(ctx context.Context) Result
+}
+
+// Registry holds the set of Checkers the daemon runs on each scrape.
+type Registry struct {
+	timeout  time.Duration
+	checkers []Checker
+}
+
+// NewRegistry returns an empty Registry that runs each check with the given
+// per-check timeout.
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds c to the set of checks run by RunAll.
+func (r *Registry) Register(c Checker) {
+	r.checkers = append(r.checkers, c)
+}
+
+// RunAll runs every registered Checker concurrently, each bounded by the
+// registry's per-check timeout and cancelled if ctx is done, and returns one
+// Result per Checker in registration order.
+func (r *Registry) RunAll(ctx context.Context) []Result {
+	results := make([]Result, len(r.checkers))
+
+	var wg sync.WaitGroup
+	for i, c := range r.checkers {
+		wg.Add(1)
+		go func(i int, c Checker) {
+			defer wg.Done()
+			results[i] = r.runOne(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (r *Registry) runOne(ctx context.Context, c Checker) Result {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := c.Run(ctx)
+	result.Name = c.Name()
+	result.Duration = time.Since(start)
+
+	if result.Err != nil {
+		log.Println("check", c.Name(), "failed:", result.Err)
+	}
+
+	return result
+}
@@ -0,0 +1,166 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// StorageCheckConfig lists which LVM volume groups and logical volume thin
+// pools to monitor, and the threshold each one is held to. A single global
+// okSize doesn't fit clusters that mix e.g. a docker-pool and a registry
+// pool with different growth characteristics.
+type StorageCheckConfig struct {
+	VolumeGroups   []VGThreshold
+	LogicalVolumes []LVThreshold
+}
+
+// VGThreshold requires volume group Name to keep at least MinFreePercent of
+// its capacity free.
+type VGThreshold struct {
+	Name           string
+	MinFreePercent int
+}
+
+// LVThreshold requires thin pool Name to keep its data and metadata usage
+// below MaxUsedPercent.
+type LVThreshold struct {
+	Name           string
+	MaxUsedPercent int
+}
+
+// vgsReport mirrors the JSON schema `vgs --reportformat json` documents for
+// -o vg_name,vg_size,vg_free.
+type vgsReport struct {
+	Report []struct {
+		VG []vgsEntry `json:"vg"`
+	} `json:"report"`
+}
+
+type vgsEntry struct {
+	Name string `json:"vg_name"`
+	Size string `json:"vg_size"`
+	Free string `json:"vg_free"`
+}
+
+// lvsReport mirrors the JSON schema `lvs --reportformat json` documents for
+// -o lv_name,data_percent,metadata_percent.
+type lvsReport struct {
+	Report []struct {
+		LV []lvsEntry `json:"lv"`
+	} `json:"report"`
+}
+
+type lvsEntry struct {
+	Name            string `json:"lv_name"`
+	DataPercent     string `json:"data_percent"`
+	MetadataPercent string `json:"metadata_percent"`
+}
+
+// CheckVolumeGroup runs `vgs --reportformat json` for the named volume
+// group and returns its free space percentage, plus an error if that
+// percentage is below threshold.MinFreePercent.
+func CheckVolumeGroup(ctx context.Context, threshold VGThreshold) (float64, error) {
+	out, err := exec.CommandContext(ctx, "vgs", "--reportformat", "json", "-o", "vg_name,vg_size,vg_free", "--units", "b", threshold.Name).Output()
+	if err != nil {
+		return 0, fmt.Errorf("run vgs for %s: %w", threshold.Name, err)
+	}
+
+	entry, err := parseVGsReport(out, threshold.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := parseLVMBytes(entry.Size)
+	if err != nil {
+		return 0, fmt.Errorf("parse vg_size for %s: %w", threshold.Name, err)
+	}
+	free, err := parseLVMBytes(entry.Free)
+	if err != nil {
+		return 0, fmt.Errorf("parse vg_free for %s: %w", threshold.Name, err)
+	}
+
+	freePercent := 100 / size * free
+	if freePercent < float64(threshold.MinFreePercent) {
+		return freePercent, fmt.Errorf("VG %s free size is below threshold: size=%v free=%v threshold=%d%%", threshold.Name, size, free, threshold.MinFreePercent)
+	}
+
+	return freePercent, nil
+}
+
+// CheckLogicalVolume runs `lvs --reportformat json` for the named thin pool
+// and returns its data and metadata usage percentages, plus an error if
+// either is at or above threshold.MaxUsedPercent.
+func CheckLogicalVolume(ctx context.Context, threshold LVThreshold) (dataPercent, metadataPercent float64, err error) {
+	out, err := exec.CommandContext(ctx, "lvs", "--reportformat", "json", "-o", "lv_name,data_percent,metadata_percent", threshold.Name).Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("run lvs for %s: %w", threshold.Name, err)
+	}
+
+	entry, err := parseLVsReport(out, threshold.Name)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dataPercent, err = strconv.ParseFloat(entry.DataPercent, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse data_percent for %s: %w", threshold.Name, err)
+	}
+	metadataPercent, err = strconv.ParseFloat(entry.MetadataPercent, 64)
+	if err != nil {
+		return dataPercent, 0, fmt.Errorf("parse metadata_percent for %s: %w", threshold.Name, err)
+	}
+
+	if dataPercent >= float64(threshold.MaxUsedPercent) || metadataPercent >= float64(threshold.MaxUsedPercent) {
+		return dataPercent, metadataPercent, fmt.Errorf("LV pool %s usage is above threshold: data=%v%% metadata=%v%% threshold=%d%%", threshold.Name, dataPercent, metadataPercent, threshold.MaxUsedPercent)
+	}
+
+	return dataPercent, metadataPercent, nil
+}
+
+func parseVGsReport(out []byte, name string) (vgsEntry, error) {
+	var report vgsReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		return vgsEntry{}, fmt.Errorf("parse vgs json output: %w", err)
+	}
+
+	for _, r := range report.Report {
+		for _, vg := range r.VG {
+			if vg.Name == name {
+				return vg, nil
+			}
+		}
+	}
+
+	return vgsEntry{}, fmt.Errorf("volume group %s not found in vgs output", name)
+}
+
+func parseLVsReport(out []byte, name string) (lvsEntry, error) {
+	var report lvsReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		return lvsEntry{}, fmt.Errorf("parse lvs json output: %w", err)
+	}
+
+	for _, r := range report.Report {
+		for _, lv := range r.LV {
+			if lv.Name == name {
+				return lv, nil
+			}
+		}
+	}
+
+	return lvsEntry{}, fmt.Errorf("logical volume %s not found in lvs output", name)
+}
+
+// parseLVMBytes parses the numeric part of an lvm2 JSON field. With
+// --units b, vgs still suffixes values with a "B" unit marker (e.g.
+// "10737418240B"), so this trims any trailing non-digit characters.
+func parseLVMBytes(s string) (float64, error) {
+	end := len(s)
+	for end > 0 && (s[end-1] < '0' || s[end-1] > '9') {
+		end--
+	}
+	return strconv.ParseFloat(s[:end], 64)
+}
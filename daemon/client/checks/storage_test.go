@@ -0,0 +1,76 @@
+package checks
+
+import "testing"
+
+func TestParseVGsReport(t *testing.T) {
+	// Sample `vgs --reportformat json -o vg_name,vg_size,vg_free --units b` output.
+	const sample = `{
+		"report": [
+			{
+				"vg": [
+					{"vg_name":"vg_fast_registry2", "vg_size":"107374182400B", "vg_free":"26843545600B"},
+					{"vg_name":"vg_slow", "vg_size":"214748364800B", "vg_free":"10737418240B"}
+				]
+			}
+		]
+	}`
+
+	entry, err := parseVGsReport([]byte(sample), "vg_fast_registry2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Size != "107374182400B" || entry.Free != "26843545600B" {
+		t.Errorf("parsed entry = %+v, want size=107374182400B free=26843545600B", entry)
+	}
+
+	if _, err := parseVGsReport([]byte(sample), "vg_missing"); err == nil {
+		t.Fatal("expected an error for a volume group not in the report, got nil")
+	}
+}
+
+func TestParseLVsReport(t *testing.T) {
+	// Sample `lvs --reportformat json -o lv_name,data_percent,metadata_percent` output.
+	const sample = `{
+		"report": [
+			{
+				"lv": [
+					{"lv_name":"docker-pool", "data_percent":"42.10", "metadata_percent":"8.86"},
+					{"lv_name":"lv_fast_registry_pool", "data_percent":"13.63", "metadata_percent":"8.93"}
+				]
+			}
+		]
+	}`
+
+	entry, err := parseLVsReport([]byte(sample), "docker-pool")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.DataPercent != "42.10" || entry.MetadataPercent != "8.86" {
+		t.Errorf("parsed entry = %+v, want data_percent=42.10 metadata_percent=8.86", entry)
+	}
+
+	if _, err := parseLVsReport([]byte(sample), "lv_missing"); err == nil {
+		t.Fatal("expected an error for a logical volume not in the report, got nil")
+	}
+}
+
+func TestParseLVMBytes(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"107374182400B", 107374182400},
+		{"0B", 0},
+		{"26843545600", 26843545600},
+	}
+
+	for _, c := range cases {
+		got, err := parseLVMBytes(c.in)
+		if err != nil {
+			t.Fatalf("parseLVMBytes(%q) returned error: %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("parseLVMBytes(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package checks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900-01-01)
+// and the Unix epoch (1970-01-01), used to convert NTP timestamps.
+const ntpEpochOffset = 2208988800
+
+// ntpClientHeader is the LI/VN/Mode byte of the client request: leap
+// indicator 0 (no warning), version 3, mode 3 (client). This is the byte
+// virtually every SNTP client sends on the wire, and NTPv4 servers answer it.
+const ntpClientHeader = 0x1B
+
+// ntpPacket is the wire layout of an NTP packet's first 48 bytes, which is
+// all a client needs to send or read back for a time check.
+type ntpPacket struct {
+	Settings       uint8
+	Stratum        uint8
+	Poll           int8
+	Precision      int8
+	RootDelay      uint32
+	RootDispersion uint32
+	ReferenceID    uint32
+	RefTimeSec     uint32
+	RefTimeFrac    uint32
+	OrigTimeSec    uint32
+	OrigTimeFrac   uint32
+	RxTimeSec      uint32
+	RxTimeFrac     uint32
+	TxTimeSec      uint32
+	TxTimeFrac     uint32
+}
+
+// CheckTimeSync queries each of servers over the SNTP/NTPv4 wire protocol
+// (UDP port 123) and returns an error if none of them report a clock offset
+// within maxOffset. Unlike shelling out to ntpq/chronyc, this works the same
+// way regardless of what (if any) time daemon is installed on the host.
+func CheckTimeSync(servers []string, maxOffset time.Duration) error {
+	if len(servers) == 0 {
+		return errors.New("no NTP servers configured")
+	}
+
+	var lastErr error
+	for _, server := range servers {
+		offset, err := queryNTPOffset(context.Background(), server, 5*time.Second)
+		if err != nil {
+			log.Println("NTP query to", server, "failed:", err)
+			lastErr = err
+			continue
+		}
+
+		log.Println("NTP offset to", server, "is", offset)
+
+		if offset < -maxOffset || offset > maxOffset {
+			lastErr = fmt.Errorf("clock offset to %s is %v, which exceeds the allowed %v", server, offset, maxOffset)
+			continue
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("none of the configured NTP servers %v reported a clock offset within %v: %w", servers, maxOffset, lastErr)
+}
+
+// queryNTPOffset sends a single SNTP client request to server:123 and
+// returns the clock offset computed from the four timestamps in the reply,
+// per RFC 5905: offset = ((T2-T1) + (T3-T4)) / 2. ctx bounds the whole
+// exchange in addition to timeout, so a caller such as the Checker registry
+// can cancel it early (e.g. on shutdown) rather than waiting out timeout.
+func queryNTPOffset(ctx context.Context, server string, timeout time.Duration) (time.Duration, error) {
+	conn, stop, err := dialContext(ctx, "udp", net.JoinHostPort(server, "123"), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("dial %s: %w", server, err)
+	}
+	defer conn.Close()
+	defer stop()
+
+	req := ntpPacket{Settings: ntpClientHeader}
+	t1 := time.Now()
+	if err := binary.Write(conn, binary.BigEndian, &req); err != nil {
+		return 0, fmt.Errorf("send NTP request to %s: %w", server, err)
+	}
+
+	var resp ntpPacket
+	if err := binary.Read(conn, binary.BigEndian, &resp); err != nil {
+		return 0, fmt.Errorf("read NTP reply from %s: %w", server, err)
+	}
+	t4 := time.Now()
+
+	if resp.Stratum == 0 {
+		return 0, fmt.Errorf("%s sent a kiss-of-death reply (stratum 0, refid %s)", server, refIDString(resp.ReferenceID))
+	}
+
+	t2 := ntpToTime(resp.RxTimeSec, resp.RxTimeFrac)
+	t3 := ntpToTime(resp.TxTimeSec, resp.TxTimeFrac)
+
+	offset := (t2.Sub(t1) + t3.Sub(t4)) / 2
+	log.Printf("NTP reply from %s: stratum %d, root dispersion %.6fs, offset %v", server, resp.Stratum, rootDispersionSeconds(resp.RootDispersion), offset)
+
+	return offset, nil
+}
+
+// rootDispersionSeconds converts an NTP short-format (16.16 fixed point)
+// root dispersion field into seconds.
+func rootDispersionSeconds(rootDispersion uint32) float64 {
+	return float64(rootDispersion) / 65536
+}
+
+// ntpToTime converts a 64-bit NTP timestamp (seconds since 1900 plus a
+// 32-bit binary fraction) into a time.Time.
+func ntpToTime(seconds, frac uint32) time.Time {
+	secs := int64(seconds) - ntpEpochOffset
+	nanos := (int64(frac) * 1e9) >> 32
+	return time.Unix(secs, nanos)
+}
+
+// refIDString renders a reference ID as the 4 ASCII characters servers
+// conventionally pack into it, as in a stratum-1 ID (e.g. "GPS\x00") or a
+// kiss-of-death code (e.g. "RATE"). The 4 bytes are returned as they are;
+// an ID holding an IPv4 address is not rendered in dotted form.
+func refIDString(id uint32) string {
+	b := []byte{byte(id >> 24), byte(id >> 16), byte(id >> 8), byte(id)}
+	return string(b)
+}
@@ -0,0 +1,37 @@
+package checks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNtpToTime(t *testing.T) {
+	// 2024-01-01T00:00:00Z is 3913056000 seconds after the NTP epoch
+	// (1900-01-01), i.e. 1704067200 seconds after the Unix epoch.
+	got := ntpToTime(3913056000, 0)
+	want := time.Unix(1704067200, 0)
+
+	if !got.Equal(want) {
+		t.Errorf("ntpToTime(3913056000, 0) = %v, want %v", got, want)
+	}
+}
+
+func TestNtpToTimeFraction(t *testing.T) {
+	// A fraction of 0x80000000 is exactly half a second.
+	got := ntpToTime(ntpEpochOffset, 0x80000000)
+	want := time.Unix(0, 500*int64(time.Millisecond))
+
+	if got.Sub(want) > time.Microsecond || want.Sub(got) > time.Microsecond {
+		t.Errorf("ntpToTime fraction = %v, want %v", got, want)
+	}
+}
+
+func TestRefIDString(t *testing.T) {
+	// "GPS\x00", the conventional stratum-1 reference ID for a GPS clock.
+	got := refIDString(0x47505300)
+	want := "GPS\x00"
+
+	if got != want {
+		t.Errorf("refIDString(0x47505300) = %q, want %q", got, want)
+	}
+}